@@ -0,0 +1,43 @@
+// Package backtest replays historical OHLCV bars through a simulated
+// matching engine so algorithms can be scored without touching a live
+// exchange. See downloader.go for populating the local store and engine.go
+// for the fee/slippage model.
+package backtest
+
+// Config selects which slice of the local historical store Run replays and
+// how the simulated matching engine prices fills.
+type Config struct {
+	DataDir      string     `json:"dataDir"      yaml:"dataDir"      toml:"dataDir"`
+	Exchange     string     `json:"exchange"     yaml:"exchange"     toml:"exchange"`
+	Symbol       string     `json:"symbol"       yaml:"symbol"       toml:"symbol"`
+	Interval     string     `json:"interval"     yaml:"interval"     toml:"interval"`
+	Fee          *FeeConfig `json:"fee"          yaml:"fee"          toml:"fee"`
+	StartingCash float64    `json:"startingCash" yaml:"startingCash" toml:"startingCash"`
+}
+
+// Decision is asked, once per bar, whether to submit an order. It returns
+// nil to stay flat for that bar.
+type Decision func(bar Bar, equity float64) (*Order)
+
+// Run replays every bar in config's exchange/symbol/interval directory, in
+// timestamp order, against a fresh MatchingEngine, and returns the resulting
+// Report. The caller's Decision is where an algorithm's trading logic
+// plugs in; Integrator.Backtest drives it from the same code path that
+// handles a live streamingCallback.
+func Run(config *Config, decide Decision) (Report, error) {
+	bars, err := LoadBars(config.DataDir, config.Exchange, config.Symbol, config.Interval)
+	if err != nil {
+		return Report{}, err
+	}
+	engine := NewMatchingEngine(config.Exchange, config.Symbol, config.Fee, config.StartingCash)
+	equityCurve := make([]float64, 0, len(bars))
+	for _, bar := range bars {
+		if order := decide(bar, engine.Equity(bar.Close)); order != nil {
+			// A rejected order (e.g. non-positive size) just means no fill
+			// this bar; Submit's error is dropped and not reported back.
+			engine.Submit(*order, bar.Close, bar.Timestamp)
+		}
+		equityCurve = append(equityCurve, engine.Equity(bar.Close))
+	}
+	return buildReport(equityCurve, engine.Fills()), nil
+}
@@ -0,0 +1,135 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Bar is a single OHLCV candle read from the local historical store.
+type Bar struct {
+	Timestamp time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+}
+
+// barDir is the on-disk layout the downloader writes and LoadBars reads:
+// datadir/<exchange>/<symbol>/<interval>/*.csv, one row per bar, columns
+// timestamp (RFC3339),open,high,low,close,volume.
+func barDir(dataDir string, exchangeName string, symbol string, interval string) (string) {
+	return filepath.Join(dataDir, exchangeName, symbol, interval)
+}
+
+// LoadBars reads every CSV file under the exchange/symbol/interval directory
+// and returns their rows sorted by timestamp.
+func LoadBars(dataDir string, exchangeName string, symbol string, interval string) ([]Bar, error) {
+	dir := barDir(dataDir, exchangeName, symbol, interval)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not read bar store (dir = %v)", dir))
+	}
+	var bars []Bar
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".csv" {
+			continue
+		}
+		fileBars, err := loadBarFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		bars = append(bars, fileBars...)
+	}
+	sort.Slice(bars, func(a int, b int) (bool) {
+		return bars[a].Timestamp.Before(bars[b].Timestamp)
+	})
+	return bars, nil
+}
+
+func loadBarFile(path string) ([]Bar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not open bar file (path = %v)", path))
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not parse bar file (path = %v)", path))
+	}
+	bars := make([]Bar, 0, len(rows))
+	for _, row := range rows {
+		if len(row) != 6 {
+			return nil, errors.Errorf("bar row has wrong column count (path = %v, row = %v)", path, row)
+		}
+		bar, err := parseBarRow(row)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("can not parse bar row (path = %v, row = %v)", path, row))
+		}
+		bars = append(bars, bar)
+	}
+	return bars, nil
+}
+
+func parseBarRow(row []string) (Bar, error) {
+	timestamp, err := time.Parse(time.RFC3339, row[0])
+	if err != nil {
+		return Bar{}, err
+	}
+	values := make([]float64, 5)
+	for idx, cell := range row[1:] {
+		value, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return Bar{}, err
+		}
+		values[idx] = value
+	}
+	return Bar{
+		Timestamp: timestamp,
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+	}, nil
+}
+
+// WriteBars writes bars to a single CSV file under the exchange/symbol/
+// interval directory, creating the directory tree if needed and replacing
+// any existing file of the same name. It is used by
+// the downloader subcommand.
+func WriteBars(dataDir string, exchangeName string, symbol string, interval string, fileName string, bars []Bar) (error) {
+	dir := barDir(dataDir, exchangeName, symbol, interval)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("can not create bar store (dir = %v)", dir))
+	}
+	path := filepath.Join(dir, fileName)
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("can not create bar file (path = %v)", path))
+	}
+	defer f.Close()
+	writer := csv.NewWriter(f)
+	for _, bar := range bars {
+		row := []string{
+			bar.Timestamp.Format(time.RFC3339),
+			strconv.FormatFloat(bar.Open, 'f', -1, 64),
+			strconv.FormatFloat(bar.High, 'f', -1, 64),
+			strconv.FormatFloat(bar.Low, 'f', -1, 64),
+			strconv.FormatFloat(bar.Close, 'f', -1, 64),
+			strconv.FormatFloat(bar.Volume, 'f', -1, 64),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,42 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/AutomaticCoinTrader/ACT/exchange"
+)
+
+// HistorySource is implemented by an exchange.Exchange that can serve past
+// bars over REST, independent of its live streaming support.
+type HistorySource interface {
+	GetHistoricalBars(symbol string, interval string, from time.Time, to time.Time) ([]exchange.Bar, error)
+}
+
+// Download pulls bars for symbol/interval between from and to from source
+// and writes them into the local store under dataDir, ready for Run to
+// replay later.
+func Download(dataDir string, exchangeName string, source HistorySource, symbol string, interval string, from time.Time, to time.Time) (error) {
+	historicalBars, err := source.GetHistoricalBars(symbol, interval, from, to)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("can not download historical bars (exchange = %v, symbol = %v)", exchangeName, symbol))
+	}
+	bars := make([]Bar, 0, len(historicalBars))
+	for _, b := range historicalBars {
+		bars = append(bars, Bar{
+			Timestamp: b.Timestamp,
+			Open:      b.Open,
+			High:      b.High,
+			Low:       b.Low,
+			Close:     b.Close,
+			Volume:    b.Volume,
+		})
+	}
+	fileName := fmt.Sprintf("%s_%s.csv", from.Format("20060102"), to.Format("20060102"))
+	if err := WriteBars(dataDir, exchangeName, symbol, interval, fileName, bars); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("can not write historical bars (exchange = %v, symbol = %v)", exchangeName, symbol))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchingEngineSubmitAppliesFeeAndSlippage(t *testing.T) {
+	fee := &FeeConfig{MakerFeeRate: 0, TakerFeeRate: 0.01, SlippageRate: 0.001}
+	engine := NewMatchingEngine("test", "BTCUSD", fee, 1000)
+
+	fill, err := engine.Submit(Order{Side: Buy, Size: 1}, 100, time.Time{})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	wantPrice := 100 + 100*fee.SlippageRate
+	if fill.Price != wantPrice {
+		t.Errorf("price = %v, want %v", fill.Price, wantPrice)
+	}
+	wantFee := wantPrice * fee.TakerFeeRate
+	if fill.Fee != wantFee {
+		t.Errorf("fee = %v, want %v", fill.Fee, wantFee)
+	}
+	wantCash := 1000 - (wantPrice*1 + wantFee)
+	if engine.Equity(wantPrice) != wantCash+1*wantPrice {
+		t.Errorf("equity = %v, want %v", engine.Equity(wantPrice), wantCash+1*wantPrice)
+	}
+}
+
+func TestMatchingEngineSubmitRejectsNonPositiveSize(t *testing.T) {
+	engine := NewMatchingEngine("test", "BTCUSD", &FeeConfig{}, 1000)
+
+	if _, err := engine.Submit(Order{Side: Buy, Size: 0}, 100, time.Time{}); err == nil {
+		t.Fatal("expected error for zero size order")
+	}
+	if len(engine.Fills()) != 0 {
+		t.Errorf("rejected order should not produce a fill, got %v", engine.Fills())
+	}
+	if engine.Equity(100) != 1000 {
+		t.Errorf("rejected order should not move cash, equity = %v", engine.Equity(100))
+	}
+}
+
+func TestMatchingEngineSellReducesPositionAndAddsCash(t *testing.T) {
+	engine := NewMatchingEngine("test", "BTCUSD", &FeeConfig{}, 1000)
+
+	if _, err := engine.Submit(Order{Side: Buy, Size: 2}, 100, time.Time{}); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+	if _, err := engine.Submit(Order{Side: Sell, Size: 1}, 110, time.Time{}); err != nil {
+		t.Fatalf("sell: %v", err)
+	}
+
+	if len(engine.Fills()) != 2 {
+		t.Fatalf("expected 2 fills, got %d", len(engine.Fills()))
+	}
+	wantCash := 1000 - 200 + 110
+	wantEquity := float64(wantCash) + 1*110
+	if got := engine.Equity(110); got != wantEquity {
+		t.Errorf("equity = %v, want %v", got, wantEquity)
+	}
+}
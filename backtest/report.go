@@ -0,0 +1,109 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// TradeLogEntry is a single fill as it appears in Report.TradeLog.
+type TradeLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Side      Side      `json:"side"`
+	Price     float64   `json:"price"`
+	Size      float64   `json:"size"`
+	Fee       float64   `json:"fee"`
+}
+
+// Report summarizes a single backtest run.
+type Report struct {
+	PnL         float64         `json:"pnl"`
+	Sharpe      float64         `json:"sharpe"`
+	MaxDrawdown float64         `json:"maxDrawdown"`
+	TradeLog    []TradeLogEntry `json:"tradeLog"`
+}
+
+// buildReport derives PnL/Sharpe/max-drawdown from an equity curve sampled
+// once per bar, plus the matching engine's fills as the trade log.
+func buildReport(equityCurve []float64, fills []Fill) (Report) {
+	tradeLog := make([]TradeLogEntry, 0, len(fills))
+	for _, fill := range fills {
+		tradeLog = append(tradeLog, TradeLogEntry{
+			Timestamp: fill.Timestamp,
+			Side:      fill.Side,
+			Price:     fill.Price,
+			Size:      fill.Size,
+			Fee:       fill.Fee,
+		})
+	}
+	return Report{
+		PnL:         pnl(equityCurve),
+		Sharpe:      sharpe(equityCurve),
+		MaxDrawdown: maxDrawdown(equityCurve),
+		TradeLog:    tradeLog,
+	}
+}
+
+func pnl(equityCurve []float64) (float64) {
+	if len(equityCurve) == 0 {
+		return 0
+	}
+	return equityCurve[len(equityCurve)-1] - equityCurve[0]
+}
+
+func returns(equityCurve []float64) ([]float64) {
+	if len(equityCurve) < 2 {
+		return nil
+	}
+	rets := make([]float64, 0, len(equityCurve)-1)
+	for idx := 1; idx < len(equityCurve); idx++ {
+		prev := equityCurve[idx-1]
+		if prev == 0 {
+			continue
+		}
+		rets = append(rets, (equityCurve[idx]-prev)/prev)
+	}
+	return rets
+}
+
+func sharpe(equityCurve []float64) (float64) {
+	rets := returns(equityCurve)
+	if len(rets) == 0 {
+		return 0
+	}
+	mean := 0.0
+	for _, r := range rets {
+		mean += r
+	}
+	mean /= float64(len(rets))
+	variance := 0.0
+	for _, r := range rets {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(rets))
+	stddev := math.Sqrt(variance)
+	if stddev == 0 {
+		return 0
+	}
+	return mean / stddev * math.Sqrt(float64(len(rets)))
+}
+
+func maxDrawdown(equityCurve []float64) (float64) {
+	if len(equityCurve) == 0 {
+		return 0
+	}
+	peak := equityCurve[0]
+	worst := 0.0
+	for _, equity := range equityCurve {
+		if equity > peak {
+			peak = equity
+		}
+		if peak == 0 {
+			continue
+		}
+		drawdown := (peak - equity) / peak
+		if drawdown > worst {
+			worst = drawdown
+		}
+	}
+	return worst
+}
@@ -0,0 +1,48 @@
+package backtest
+
+import "testing"
+
+func TestPnlIsLastMinusFirstEquity(t *testing.T) {
+	if got := pnl([]float64{100, 90, 120}); got != 20 {
+		t.Errorf("pnl = %v, want 20", got)
+	}
+	if got := pnl(nil); got != 0 {
+		t.Errorf("pnl of empty curve = %v, want 0", got)
+	}
+}
+
+func TestMaxDrawdownTracksWorstPeakToTrough(t *testing.T) {
+	curve := []float64{100, 120, 60, 90}
+	got := maxDrawdown(curve)
+	want := (120.0 - 60.0) / 120.0
+	if got != want {
+		t.Errorf("maxDrawdown = %v, want %v", got, want)
+	}
+}
+
+func TestMaxDrawdownOfMonotonicGainIsZero(t *testing.T) {
+	if got := maxDrawdown([]float64{100, 110, 120}); got != 0 {
+		t.Errorf("maxDrawdown = %v, want 0", got)
+	}
+}
+
+func TestSharpeOfFlatCurveIsZero(t *testing.T) {
+	if got := sharpe([]float64{100, 100, 100}); got != 0 {
+		t.Errorf("sharpe of flat equity = %v, want 0", got)
+	}
+}
+
+func TestBuildReportCarriesFillsIntoTradeLog(t *testing.T) {
+	fills := []Fill{{Side: Buy, Price: 100, Size: 1, Fee: 0.1}}
+	report := buildReport([]float64{1000, 1000.9}, fills)
+
+	if len(report.TradeLog) != 1 {
+		t.Fatalf("expected 1 trade log entry, got %d", len(report.TradeLog))
+	}
+	if report.TradeLog[0].Price != 100 {
+		t.Errorf("trade log price = %v, want 100", report.TradeLog[0].Price)
+	}
+	if diff := report.PnL - 0.9; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("pnl = %v, want 0.9", report.PnL)
+	}
+}
@@ -0,0 +1,115 @@
+package integrator
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type healthStatus struct {
+	Status    string          `json:"status"`
+	Exchanges map[string]bool `json:"exchanges"`
+}
+
+// healthz reports liveness: the process is up and serving requests.
+func (i *Integrator) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readyz reports readiness, including whether each exchange with a
+// configured streaming client currently has a live connection. Exchanges
+// with no streaming client configured don't affect readiness.
+func (i *Integrator) readyz(c *gin.Context) {
+	status := healthStatus{Status: "ok", Exchanges: make(map[string]bool)}
+	for name, client := range i.streamingClients {
+		connected := client.Connected()
+		status.Exchanges[name] = connected
+		if !connected {
+			status.Status = "degraded"
+		}
+	}
+	code := http.StatusOK
+	if status.Status != "ok" {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, status)
+}
+
+type exchangeSummary struct {
+	Name      string   `json:"name"`
+	TradeIDs  []string `json:"tradeIds"`
+	Streaming bool     `json:"streaming"`
+}
+
+// getExchanges lists every loaded exchange with the trade contexts it
+// created during startStreaming.
+func (i *Integrator) getExchanges(c *gin.Context) {
+	summaries := make([]exchangeSummary, 0, len(i.exchanges))
+	for name := range i.exchanges {
+		tradeIDs := make([]string, 0, len(i.tradeContexts[name]))
+		for tradeID := range i.tradeContexts[name] {
+			tradeIDs = append(tradeIDs, tradeID)
+		}
+		client, ok := i.streamingClients[name]
+		summaries = append(summaries, exchangeSummary{
+			Name:      name,
+			TradeIDs:  tradeIDs,
+			Streaming: ok && client.Connected(),
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"exchanges": summaries})
+}
+
+// algorithmStatus is one trade's most recent streamingCallback outcome, as
+// recorded by streamingCallback every time it runs UpdateTradeAlgorithms.
+type algorithmStatus struct {
+	TradeID        string    `json:"tradeId"`
+	LastDecisionAt time.Time `json:"lastDecisionAt"`
+	LastError      string    `json:"lastError,omitempty"`
+}
+
+// getAlgorithms lists every running trade's algorithm, including ones only
+// registered through the static robot config (not just hot-reloaded
+// plugins/scripts), alongside its most recent decision. loadedStrategies is
+// the strategy.Loader's view of what's been scanned/reloaded from disk,
+// kept separate since a loaded strategy may not be driving a running trade.
+func (i *Integrator) getAlgorithms(c *gin.Context) {
+	i.decisionMu.Lock()
+	statuses := make([]algorithmStatus, 0, len(i.decisions))
+	seen := make(map[string]bool, len(i.decisions))
+	for tradeID, status := range i.decisions {
+		statuses = append(statuses, status)
+		seen[tradeID] = true
+	}
+	i.decisionMu.Unlock()
+	for _, tradeContexts := range i.tradeContexts {
+		for tradeID := range tradeContexts {
+			if seen[tradeID] {
+				continue
+			}
+			statuses = append(statuses, algorithmStatus{TradeID: tradeID})
+			seen[tradeID] = true
+		}
+	}
+	loadedStrategies := []string{}
+	if i.strategyLoader != nil {
+		loadedStrategies = i.strategyLoader.Loaded()
+	}
+	c.JSON(http.StatusOK, gin.H{"algorithms": statuses, "loadedStrategies": loadedStrategies})
+}
+
+// getPositions snapshots current holdings across every loaded exchange.
+func (i *Integrator) getPositions(c *gin.Context) {
+	positions := make(map[string]map[string]float64, len(i.exchanges))
+	for name, ex := range i.exchanges {
+		balances, err := ex.GetBalances()
+		if err != nil {
+			i.logger.Error("can not read balances", zap.String("exchange", name), zap.Error(err))
+			continue
+		}
+		positions[name] = balances
+	}
+	c.JSON(http.StatusOK, gin.H{"positions": positions})
+}
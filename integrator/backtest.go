@@ -0,0 +1,80 @@
+package integrator
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/AutomaticCoinTrader/ACT/backtest"
+	"github.com/AutomaticCoinTrader/ACT/exchange"
+)
+
+// backtestTradeContext adapts a single backtest.Bar into the same
+// exchange.TradeContext surface streamingCallback expects from a live
+// exchange, so algorithm code runs unmodified in both modes. Unlike a live
+// TradeContext it does not forward orders to an exchange; PlaceOrder just
+// records the algorithm's intent in pendingOrder so Backtest's Decision
+// closure can hand it to the MatchingEngine. It deliberately does not embed
+// exchange.TradeContext: a method this doesn't implement should be a build
+// failure here, not a nil-interface panic mid-backtest.
+type backtestTradeContext struct {
+	symbol       string
+	bar          backtest.Bar
+	pendingOrder *backtest.Order
+}
+
+var _ exchange.TradeContext = (*backtestTradeContext)(nil)
+
+func (t *backtestTradeContext) GetID() (string) {
+	return t.symbol
+}
+
+// GetPrice returns the bar's close as the current market price, the same
+// value Backtest prices fills against.
+func (t *backtestTradeContext) GetPrice() (float64) {
+	return t.bar.Close
+}
+
+// PlaceOrder is the order-placement entry point backtestTradeContext gives
+// algorithm code in place of a live exchange call; it records the order
+// instead of sending it anywhere, so Backtest can feed it to the
+// MatchingEngine after UpdateTradeAlgorithms returns. Only one pending order
+// per bar is supported, matching Decision's one-order-per-bar contract.
+func (t *backtestTradeContext) PlaceOrder(side backtest.Side, price float64, size float64, maker bool) (error) {
+	t.pendingOrder = &backtest.Order{Side: side, Price: price, Size: size, Maker: maker}
+	return nil
+}
+
+// Backtest replays the historical bars selected by cfg through the same
+// robot.CreateTradeAlgorithms/UpdateTradeAlgorithms/DestroyTradeAlgorithms
+// lifecycle startStreaming/stopStreaming drive live, via a
+// backtest.MatchingEngine standing in for the exchange, and returns the
+// resulting backtest.Report. The algorithm expresses its order intent by
+// calling PlaceOrder on the tradeContext it is handed; Decision returns
+// whatever ends up in pendingOrder so it reaches engine.Submit.
+func (i *Integrator) Backtest(cfg *backtest.Config) (backtest.Report, error) {
+	lifecycleContext := &backtestTradeContext{symbol: cfg.Symbol}
+	if err := i.robot.CreateTradeAlgorithms(cfg.Symbol, lifecycleContext); err != nil {
+		return backtest.Report{}, errors.Wrap(err, fmt.Sprintf("can not create algorithm for backtest (exchange = %v, symbol = %v)", cfg.Exchange, cfg.Symbol))
+	}
+	defer func() {
+		if err := i.robot.DestroyTradeAlgorithms(cfg.Symbol, lifecycleContext); err != nil {
+			i.logger.Error("can not destroy algorithm after backtest", zap.String("symbol", cfg.Symbol), zap.Error(err))
+		}
+	}()
+	report, err := backtest.Run(cfg, func(bar backtest.Bar, equity float64) (*backtest.Order) {
+		tradeContext := &backtestTradeContext{symbol: cfg.Symbol, bar: bar}
+		if err := i.robot.UpdateTradeAlgorithms(cfg.Symbol, tradeContext); err != nil {
+			i.logger.Error("can not run algorithm during backtest", zap.String("symbol", cfg.Symbol), zap.Error(err))
+		}
+		if err := i.robot.UpdateArbitrageTradeAlgorithms(i.exchanges); err != nil {
+			i.logger.Error("can not run arbitrage algorithm during backtest", zap.Error(err))
+		}
+		return tradeContext.pendingOrder
+	})
+	if err != nil {
+		return backtest.Report{}, errors.Wrap(err, fmt.Sprintf("can not run backtest (exchange = %v, symbol = %v)", cfg.Exchange, cfg.Symbol))
+	}
+	return report, nil
+}
@@ -0,0 +1,71 @@
+package integrator
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/AutomaticCoinTrader/ACT/metrics"
+	"github.com/AutomaticCoinTrader/ACT/streaming"
+)
+
+// dispatcher fans streaming.Message values out to the TradeAlgorithm
+// instances registered for the message's exchange/symbol, reusing the same
+// robot.UpdateTradeAlgorithms path that the polling-based streamingCallback
+// already drives.
+type dispatcher struct {
+	mu   sync.RWMutex
+	subs map[string][]*streaming.Subscription
+}
+
+func newDispatcher() (*dispatcher) {
+	return &dispatcher{
+		subs: make(map[string][]*streaming.Subscription),
+	}
+}
+
+func dispatcherKey(exchangeName string, symbol string) (string) {
+	return fmt.Sprintf("%s:%s", exchangeName, symbol)
+}
+
+// Subscribe uses the streaming.Client that Initialize registered for
+// exchangeName (it returns an error when there is none; it does not open a
+// new one) and subscribes symbol to the requested channels, fanning every received
+// streaming.Message into i.streamingCallback via the exchange's existing
+// TradeContext. The returned streaming.Subscription can be used to cancel
+// just this call's interest in symbol.
+func (i *Integrator) Subscribe(exchangeName string, symbol string, channels ...streaming.ChannelType) (*streaming.Subscription, error) {
+	client, ok := i.streamingClients[exchangeName]
+	if !ok {
+		return nil, errors.Errorf("no streaming client registered (exchange = %v)", exchangeName)
+	}
+	if _, ok := i.exchanges[exchangeName]; !ok {
+		return nil, errors.Errorf("no exchange registered (exchange = %v)", exchangeName)
+	}
+	sub, err := client.Subscribe(symbol, func(message streaming.Message) {
+		metrics.StreamingMessagesReceived.WithLabelValues(exchangeName, symbol, string(message.Channel)).Inc()
+		tradeContext, ok := i.tradeContexts[exchangeName][symbol]
+		if !ok {
+			return
+		}
+		if applier, ok := tradeContext.(streaming.MessageApplier); ok {
+			if err := applier.ApplyMessage(message); err != nil {
+				i.logger.Error("can not apply streaming message", zap.String("exchange", exchangeName), zap.String("symbol", symbol), zap.Error(err))
+				return
+			}
+		}
+		if err := i.streamingCallback(tradeContext, message); err != nil {
+			return
+		}
+	}, channels...)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not subscribe (exchange = %v, symbol = %v)", exchangeName, symbol))
+	}
+	key := dispatcherKey(exchangeName, symbol)
+	i.dispatcher.mu.Lock()
+	i.dispatcher.subs[key] = append(i.dispatcher.subs[key], sub)
+	i.dispatcher.mu.Unlock()
+	return sub, nil
+}
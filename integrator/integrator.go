@@ -6,11 +6,19 @@ import (
 	"github.com/braintree/manners"
 	"github.com/AutomaticCoinTrader/ACT/exchange"
 	"github.com/AutomaticCoinTrader/ACT/robot"
-	"log"
+	"github.com/AutomaticCoinTrader/ACT/streaming"
+	"github.com/AutomaticCoinTrader/ACT/strategy"
+	"github.com/AutomaticCoinTrader/ACT/algorithm"
+	"github.com/AutomaticCoinTrader/ACT/logging"
+	"github.com/AutomaticCoinTrader/ACT/metrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
 	"time"
 	"fmt"
 	"reflect"
 	"net/http"
+	"path/filepath"
+	"sync"
 	"github.com/AutomaticCoinTrader/ACT/notifier"
 )
 
@@ -28,16 +36,51 @@ type gracefulServer struct {
 
 type Integrator struct {
 	config                  *Config
+	configDir               string
 	gracefulServer          *gracefulServer
 	exchanges               map[string]exchange.Exchange
 	arbitrageLoopFinishChan chan bool
 	notifier                *notifier.Notifier
 	robot                   *robot.Robot
+	streamingClients        map[string]*streaming.Client
+	dispatcher              *dispatcher
+	tradeContexts           map[string]map[string]exchange.TradeContext
+	strategyLoader          *strategy.Loader
+	logger                  *zap.Logger
+	logLevel                *zap.AtomicLevel
+	decisionMu              sync.Mutex
+	decisions               map[string]algorithmStatus
 }
 
 func (i *Integrator) setupRouting(engine *gin.Engine) {
 	engine.HEAD( "/", i.index)
 	engine.GET( "/", i.index)
+	engine.GET("/log/level", gin.WrapH(i.logLevel))
+	engine.PUT("/log/level", gin.WrapH(i.logLevel))
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	engine.GET("/healthz", i.healthz)
+	engine.GET("/readyz", i.readyz)
+	api := engine.Group("/api/v1", i.authMiddleware())
+	api.GET("/exchanges", i.getExchanges)
+	api.GET("/algorithms", i.getAlgorithms)
+	api.GET("/positions", i.getPositions)
+}
+
+// authMiddleware rejects requests that do not carry the configured bearer
+// token. It is a no-op (everything allowed) when no token is configured,
+// matching the opt-in Debug behaviour of the rest of serverConfig.
+func (i *Integrator) authMiddleware() (gin.HandlerFunc) {
+	return func(c *gin.Context) {
+		if i.config.Server.AuthToken == "" {
+			c.Next()
+			return
+		}
+		if c.GetHeader("Authorization") != "Bearer "+i.config.Server.AuthToken {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
 }
 
 func (i *Integrator) runHttpServer() {
@@ -79,10 +122,17 @@ func (i *Integrator) initHttpServer() (error) {
 func (i *Integrator) streamingCallback(tradeContext exchange.TradeContext, userCallbackData interface{}) (error) {
 	// トレード処理を期待
 	tradeID := tradeContext.GetID()
+	start := time.Now()
 	err := i.robot.UpdateTradeAlgorithms(tradeID, tradeContext)
+	metrics.AlgorithmUpdateDuration.WithLabelValues(tradeID).Observe(time.Since(start).Seconds())
+	status := algorithmStatus{TradeID: tradeID, LastDecisionAt: start}
 	if err != nil {
-		log.Printf("can not run algorithm (reason = %v)", err)
+		i.logger.Error("can not run algorithm", zap.String("trade_id", tradeID), zap.Error(err))
+		status.LastError = err.Error()
 	}
+	i.decisionMu.Lock()
+	i.decisions[tradeID] = status
+	i.decisionMu.Unlock()
 	return nil
 }
 
@@ -111,8 +161,9 @@ func (i *Integrator) Initialize() (error) {
 			if exchangeNewFunc == nil {
 				continue
 			}
-			log.Printf("%v exchange create", name)
-			ex, err :=  exchangeNewFunc(conf)
+			exchangeLogger := i.logger.Named(name).With(zap.String("exchange", name))
+			exchangeLogger.Info("exchange create")
+			ex, err :=  exchangeNewFunc(conf, exchangeLogger)
 			if err != nil {
 				i.Finalize()
 				return errors.Wrap(err, fmt.Sprintf("can not create exchange of %v", name))
@@ -120,12 +171,38 @@ func (i *Integrator) Initialize() (error) {
 			ex.Initialize(i.streamingCallback, nil)
 			// 作った取引所を保存しておく
 			i.exchanges[name] = ex
+			if clientConfig, ok := i.config.Streaming[name]; ok {
+				client, err := streaming.NewClient(name, clientConfig, nil)
+				if err != nil {
+					i.Finalize()
+					return errors.Wrap(err, fmt.Sprintf("can not create streaming client of %v", name))
+				}
+				if err := client.Connect(); err != nil {
+					i.Finalize()
+					return errors.Wrap(err, fmt.Sprintf("can not connect streaming client of %v", name))
+				}
+				i.streamingClients[name] = client
+			}
 		}
 	}
+	i.strategyLoader = strategy.NewLoader(filepath.Join(i.configDir, algorithm.AlgorithmConfigDir), i.robot)
+	if err := i.strategyLoader.Scan(); err != nil {
+		i.Finalize()
+		return errors.Wrap(err, "can not scan strategy directory")
+	}
+	if err := i.strategyLoader.Watch(); err != nil {
+		i.Finalize()
+		return errors.Wrap(err, "can not watch strategy directory")
+	}
 	return nil
 }
 
 func (i *Integrator) Finalize() (error) {
+	if i.strategyLoader != nil {
+		if err := i.strategyLoader.Close(); err != nil {
+			i.logger.Error("can not stop strategy loader", zap.Error(err))
+		}
+	}
 	i.gracefulServer.server.BlockingClose()
 	return nil
 }
@@ -151,6 +228,11 @@ func (i *Integrator) startStreaming() (error) {
 				i.stopStreaming()
 				return errors.Wrap(err, fmt.Sprintf("can not start streaming (name = %v)", ex.GetName()))
 			}
+			// i.Subscribe looks tradeContext up by exchange/symbol, so keep it around
+			if _, ok := i.tradeContexts[ex.GetName()]; !ok {
+				i.tradeContexts[ex.GetName()] = make(map[string]exchange.TradeContext)
+			}
+			i.tradeContexts[ex.GetName()][tradeContext.GetID()] = tradeContext
 		}
 	}
 
@@ -169,13 +251,13 @@ func (i *Integrator) stopStreaming() (error) {
 			// streamingを停止
 			err := ex.StopStreaming(tradeContext)
 			if err != nil {
-				log.Printf("can not stop streaming (name = %v)", ex.GetName())
+				i.logger.Error("can not stop streaming", zap.String("exchange", ex.GetName()))
 			}
 			// straming止めた後の終了処理を期待
 			tradeID := tradeContext.GetID()
 			err = i.robot.DestroyTradeAlgorithms(tradeID, tradeContext)
 			if err != nil {
-				log.Printf("can not destroy algorithm (name = %v, reason = %v)", ex.GetName(), err)
+				i.logger.Error("can not destroy algorithm", zap.String("exchange", ex.GetName()), zap.Error(err))
 			}
 		}
 	}
@@ -188,9 +270,11 @@ func (i *Integrator) ArbitrageLoop (){
 		case <- i.arbitrageLoopFinishChan:
 			return
 		case <- time.After(500 * time.Millisecond):
+			start := time.Now()
 			err := i.robot.UpdateArbitrageTradeAlgorithms(i.exchanges)
+			metrics.ArbitrageLoopIterationDuration.Observe(time.Since(start).Seconds())
 			if err != nil {
-				log.Printf("can not update arbitrage algorithm (reason = %v)", err)
+				i.logger.Error("can not update arbitrage algorithm", zap.Error(err))
 			}
 		}
 	}
@@ -209,7 +293,7 @@ func (i *Integrator) stopArbitrageTrade() (error) {
 	close(i.arbitrageLoopFinishChan)
 	err := i.robot.DestroyArbitrageTradeAlgorithms(i.exchanges)
 	if err != nil {
-		log.Printf("can not destroy arbitrage algorithm (reason = %v)", err)
+		i.logger.Error("can not destroy arbitrage algorithm", zap.Error(err))
 	}
 	return nil
 }
@@ -229,11 +313,11 @@ func (i *Integrator) Start() (error) {
 func (i *Integrator) Stop() (error) {
 	err := i.stopArbitrageTrade()
 	if err != nil {
-		log.Printf("can not stop arbitarage (reason = %v)", err)
+		i.logger.Error("can not stop arbitarage", zap.Error(err))
 	}
 	err = i.stopStreaming()
 	if err != nil {
-		log.Printf("can not stop streaming (reason = %v)", err)
+		i.logger.Error("can not stop streaming", zap.Error(err))
 	}
 	return nil
 }
@@ -241,30 +325,44 @@ func (i *Integrator) Stop() (error) {
 type serverConfig struct {
 	Debug bool                 `json:"debug"     yaml:"debug"     toml:"debug"`
 	AddrPort string            `json:"addrPort"  yaml:"addrPort"  toml:"addrPort"`
+	AuthToken string           `json:"authToken" yaml:"authToken" toml:"authToken"`
 }
 
 type Config struct {
-	Server    *serverConfig     `json:"server"    yaml:"server"    toml:"server"`
-	Exchanges *exchangesConfig  `json:"exchanges" yaml:"exchanges" toml:"exchanges"`
-	Robot      *robot.Config    `json:"robot"     yaml:"robot"     toml:"robot"`
-	Notifier   *notifier.Config `json:"notifier"  yaml:"notifier"  toml:"notifier"`
+	Server    *serverConfig                        `json:"server"    yaml:"server"    toml:"server"`
+	Exchanges *exchangesConfig                      `json:"exchanges" yaml:"exchanges" toml:"exchanges"`
+	Robot      *robot.Config                        `json:"robot"     yaml:"robot"     toml:"robot"`
+	Notifier   *notifier.Config                     `json:"notifier"  yaml:"notifier"  toml:"notifier"`
+	Streaming  map[string]*streaming.ClientConfig    `json:"streaming" yaml:"streaming" toml:"streaming"`
+	Logging    *logging.Config                      `json:"logging"   yaml:"logging"   toml:"logging"`
 }
 
 func NewIntegrator(config *Config, configDir string) (*Integrator, error) {
+	logger, logLevel, err := logging.New(config.Logging)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not create logger (config dir = %v, reason = %v)", configDir, err))
+	}
 	ntf, err := notifier.NewNotifier(config.Notifier)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("can not create notifier (config dir = %v, reason = %v)", configDir, err))
 	}
-	rbt, err := robot.NewRobot(config.Robot, configDir, ntf)
+	rbt, err := robot.NewRobot(config.Robot, configDir, ntf, logger.Named("robot"))
 	if err != nil {
 		return nil, errors.Wrap(err,fmt.Sprintf("can not create robot (config dir = %v, reason = %v)", configDir, err))
 	}
 	return &Integrator{
 		config: config,
+		configDir: configDir,
 		exchanges: make(map[string]exchange.Exchange),
 		arbitrageLoopFinishChan: make(chan bool),
 		notifier: ntf,
 		robot: rbt,
+		streamingClients: make(map[string]*streaming.Client),
+		dispatcher: newDispatcher(),
+		tradeContexts: make(map[string]map[string]exchange.TradeContext),
+		logger: logger,
+		logLevel: logLevel,
+		decisions: make(map[string]algorithmStatus),
 	}, nil
 }
 
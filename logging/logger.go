@@ -0,0 +1,68 @@
+// Package logging builds the *zap.Logger used across ACT so every
+// exchange/algorithm/trade can attach contextual fields to its log lines
+// instead of the ad-hoc log.Printf calls that used to be scattered through
+// integrator.go.
+package logging
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Config controls the *zap.Logger built by New.
+type Config struct {
+	Level       string   `json:"level"       yaml:"level"       toml:"level"`
+	Encoding    string   `json:"encoding"    yaml:"encoding"    toml:"encoding"`
+	OutputPaths []string `json:"outputPaths" yaml:"outputPaths" toml:"outputPaths"`
+	Sampling    bool     `json:"sampling"    yaml:"sampling"    toml:"sampling"`
+}
+
+// New builds a *zap.Logger from config along with the zap.AtomicLevel
+// backing it. The AtomicLevel implements http.Handler, so it can be mounted
+// directly as a debug endpoint to bump verbosity on a live session without a
+// restart.
+func New(config *Config) (*zap.Logger, *zap.AtomicLevel, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	level := zap.NewAtomicLevel()
+	if config.Level == "" {
+		config.Level = "info"
+	}
+	if err := level.UnmarshalText([]byte(config.Level)); err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("can not parse log level (level = %v)", config.Level))
+	}
+	encoding := config.Encoding
+	if encoding == "" {
+		encoding = "console"
+	}
+	outputPaths := config.OutputPaths
+	if len(outputPaths) == 0 {
+		outputPaths = []string{"stdout"}
+	}
+	zapConfig := zap.Config{
+		Level:            level,
+		Encoding:         encoding,
+		OutputPaths:      outputPaths,
+		ErrorOutputPaths: []string{"stderr"},
+		EncoderConfig:    encoderConfig(encoding),
+	}
+	if config.Sampling {
+		zapConfig.Sampling = &zap.SamplingConfig{Initial: 100, Thereafter: 100}
+	}
+	logger, err := zapConfig.Build()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "can not build logger")
+	}
+	return logger, &level, nil
+}
+
+func encoderConfig(encoding string) (zapcore.EncoderConfig) {
+	if encoding == "json" {
+		return zap.NewProductionEncoderConfig()
+	}
+	return zap.NewDevelopmentEncoderConfig()
+}
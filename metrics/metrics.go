@@ -0,0 +1,66 @@
+// Package metrics defines the Prometheus collectors ACT exposes on
+// /metrics. Collectors are package-level so exchange.Exchange
+// implementations and the robot/notifier packages can record against them
+// without importing the integrator package.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	StreamingMessagesReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "act",
+		Subsystem: "streaming",
+		Name:      "messages_received_total",
+		Help:      "Streaming messages received, by exchange/symbol/channel.",
+	}, []string{"exchange", "symbol", "channel"})
+
+	AlgorithmUpdateDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "act",
+		Subsystem: "algorithm",
+		Name:      "update_duration_seconds",
+		Help:      "Time spent running every TradeAlgorithm registered for a trade.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"trade_id"})
+
+	ArbitrageLoopIterationDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "act",
+		Subsystem: "arbitrage",
+		Name:      "loop_iteration_duration_seconds",
+		Help:      "Time spent in a single ArbitrageLoop iteration.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	OrdersSubmitted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "act",
+		Subsystem: "order",
+		Name:      "submitted_total",
+		Help:      "Orders submitted, by exchange/symbol/side.",
+	}, []string{"exchange", "symbol", "side"})
+
+	OrdersAcked = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "act",
+		Subsystem: "order",
+		Name:      "acked_total",
+		Help:      "Orders acknowledged by the exchange, by exchange/symbol/side.",
+	}, []string{"exchange", "symbol", "side"})
+
+	OrdersRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "act",
+		Subsystem: "order",
+		Name:      "rejected_total",
+		Help:      "Orders rejected by the exchange, by exchange/symbol/side.",
+	}, []string{"exchange", "symbol", "side"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		StreamingMessagesReceived,
+		AlgorithmUpdateDuration,
+		ArbitrageLoopIterationDuration,
+		OrdersSubmitted,
+		OrdersAcked,
+		OrdersRejected,
+	)
+}
@@ -0,0 +1,19 @@
+package strategy
+
+// backend knows how to turn a single strategy file on disk into a live
+// algorithm. pluginBackend and scriptBackend are the two implementations.
+type backend interface {
+	// Ext is the file extension (including the leading dot) this backend
+	// handles, e.g. ".so" or ".gop".
+	Ext() (string)
+	// Load reads path and returns the strategy it defines. The strategy is
+	// either a sdk.TradeAlgorithm or a sdk.ArbitrageTradeAlgorithm.
+	Load(path string) (interface{}, error)
+}
+
+func backends() ([]backend) {
+	return []backend{
+		pluginBackend{},
+		scriptBackend{},
+	}
+}
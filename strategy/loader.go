@@ -0,0 +1,145 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/AutomaticCoinTrader/ACT/algorithm"
+	"github.com/AutomaticCoinTrader/ACT/robot"
+)
+
+// Loader discovers TradeAlgorithm/ArbitrageTradeAlgorithm implementations
+// dropped into a config directory as compiled `.so` plugins or `.gop`
+// scripts, registers them with robot.Robot, and reloads a file with the
+// same backends whenever fsnotify reports it changed.
+type Loader struct {
+	dir     string
+	robot   *robot.Robot
+	mu      sync.Mutex
+	watcher *fsnotify.Watcher
+	finish  chan bool
+	loaded  map[string]string
+}
+
+// NewLoader creates a Loader that scans and watches dir.
+func NewLoader(dir string, rbt *robot.Robot) (*Loader) {
+	return &Loader{
+		dir:    dir,
+		robot:  rbt,
+		finish: make(chan bool),
+		loaded: make(map[string]string),
+	}
+}
+
+// Loaded returns the GetName() of every strategy currently registered,
+// whether it came from the initial Scan or a hot reload.
+func (l *Loader) Loaded() ([]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.loaded))
+	for _, name := range l.loaded {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Scan loads every strategy file currently in the directory.
+func (l *Loader) Scan() (error) {
+	for _, b := range backends() {
+		matches, err := filepath.Glob(filepath.Join(l.dir, "*"+b.Ext()))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("can not scan strategy directory (dir = %v)", l.dir))
+		}
+		for _, path := range matches {
+			if err := l.load(b, path); err != nil {
+				log.Printf("can not load strategy (path = %v, reason = %v)", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *Loader) load(b backend, path string) (error) {
+	strategy, err := b.Load(path)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch a := strategy.(type) {
+	case algorithm.TradeAlgorithm:
+		if err := l.robot.RegisterTradeAlgorithm(a); err != nil {
+			return err
+		}
+		l.loaded[path] = a.GetName()
+		return nil
+	case algorithm.ArbitrageTradeAlgorithm:
+		if err := l.robot.RegisterArbitrageTradeAlgorithm(a); err != nil {
+			return err
+		}
+		l.loaded[path] = a.GetName()
+		return nil
+	default:
+		return errors.Errorf("strategy is neither a TradeAlgorithm nor an ArbitrageTradeAlgorithm (path = %v)", path)
+	}
+}
+
+// Watch starts watching the directory and reloads a file through its
+// matching backend whenever fsnotify reports it was created or written.
+func (l *Loader) Watch() (error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("can not watch strategy directory (dir = %v)", l.dir))
+	}
+	if err := watcher.Add(l.dir); err != nil {
+		watcher.Close()
+		return errors.Wrap(err, fmt.Sprintf("can not watch strategy directory (dir = %v)", l.dir))
+	}
+	l.watcher = watcher
+	go l.watchLoop()
+	return nil
+}
+
+func (l *Loader) watchLoop() {
+	for {
+		select {
+		case event, ok := <-l.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			ext := filepath.Ext(event.Name)
+			for _, b := range backends() {
+				if b.Ext() != ext {
+					continue
+				}
+				if err := l.load(b, event.Name); err != nil {
+					log.Printf("can not reload strategy (path = %v, reason = %v)", event.Name, err)
+				}
+			}
+		case err, ok := <-l.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("strategy watcher error (dir = %v, reason = %v)", l.dir, err)
+		case <-l.finish:
+			return
+		}
+	}
+}
+
+// Close stops watching the directory.
+func (l *Loader) Close() (error) {
+	close(l.finish)
+	if l.watcher == nil {
+		return nil
+	}
+	return l.watcher.Close()
+}
@@ -0,0 +1,68 @@
+package strategy
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+
+	"github.com/pkg/errors"
+
+	"github.com/AutomaticCoinTrader/ACT/strategy/sdk"
+)
+
+// pluginBackend loads compiled Go plugins (`go build -buildmode=plugin`)
+// exporting a NewAlgorithm function. Go plugins can never be reloaded or
+// unloaded from the same path for the life of the process, so a rebuilt
+// revision written back to path must be opened from a path plugin.Open has
+// never seen; Load stages path under a name derived from its contents so
+// every distinct revision gets its own pluginpath.
+type pluginBackend struct{}
+
+func (pluginBackend) Ext() (string) {
+	return ".so"
+}
+
+func (pluginBackend) Load(path string) (interface{}, error) {
+	stagedPath, err := stageByContentHash(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := plugin.Open(stagedPath)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not open strategy plugin (path = %v)", path))
+	}
+	sym, err := p.Lookup(sdk.NewAlgorithmSymbol)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("strategy plugin has no %v symbol (path = %v)", sdk.NewAlgorithmSymbol, path))
+	}
+	switch newAlgorithm := sym.(type) {
+	case func() (sdk.TradeAlgorithm, error):
+		return newAlgorithm()
+	case func() (sdk.ArbitrageTradeAlgorithm, error):
+		return newAlgorithm()
+	default:
+		return nil, errors.Errorf("strategy plugin %v symbol has unexpected signature (path = %v)", sdk.NewAlgorithmSymbol, path)
+	}
+}
+
+// stageByContentHash copies path into the system temp directory under a
+// name that includes a hash of its contents, so a file rewritten at the
+// same path hashes to a different pluginpath and plugin.Open actually loads
+// the new revision instead of returning the one it already has cached.
+func stageByContentHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("can not read strategy plugin (path = %v)", path))
+	}
+	sum := sha256.Sum256(data)
+	stagedPath := filepath.Join(os.TempDir(), fmt.Sprintf("act-strategy-%s-%x.so", filepath.Base(path), sum[:8]))
+	if _, err := os.Stat(stagedPath); err == nil {
+		return stagedPath, nil
+	}
+	if err := os.WriteFile(stagedPath, data, 0644); err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("can not stage strategy plugin (path = %v)", stagedPath))
+	}
+	return stagedPath, nil
+}
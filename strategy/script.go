@@ -0,0 +1,43 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/goplus/igop"
+	_ "github.com/goplus/igop/gopbuild"
+	"github.com/pkg/errors"
+
+	"github.com/AutomaticCoinTrader/ACT/strategy/sdk"
+)
+
+// scriptBackend interprets Go+ (.gop) source files with igop so strategies
+// can be edited and hot-reloaded without a recompile of ACT itself.
+type scriptBackend struct{}
+
+func (scriptBackend) Ext() (string) {
+	return ".gop"
+}
+
+func (scriptBackend) Load(path string) (interface{}, error) {
+	ctx := igop.NewContext(0)
+	_, err := ctx.LoadFile(path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not compile strategy script (path = %v)", path))
+	}
+	pkg, err := ctx.Source(path)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("can not load strategy script (path = %v)", path))
+	}
+	sym, ok := pkg.Lookup(sdk.NewAlgorithmSymbol)
+	if !ok {
+		return nil, errors.Errorf("strategy script has no %v function (path = %v)", sdk.NewAlgorithmSymbol, path)
+	}
+	switch newAlgorithm := sym.(type) {
+	case func() (sdk.TradeAlgorithm, error):
+		return newAlgorithm()
+	case func() (sdk.ArbitrageTradeAlgorithm, error):
+		return newAlgorithm()
+	default:
+		return nil, errors.Errorf("strategy script %v has unexpected signature (path = %v)", sdk.NewAlgorithmSymbol, path)
+	}
+}
@@ -0,0 +1,28 @@
+// Package sdk is the surface strategy backends (compiled plugins and Go+
+// scripts) are built against. It re-exports the same TradeContext/Notifier
+// types algorithm.go uses so a strategy author writes against one API
+// regardless of which backend ends up loading their code.
+package sdk
+
+import (
+	"github.com/AutomaticCoinTrader/ACT/algorithm"
+	"github.com/AutomaticCoinTrader/ACT/exchange"
+	"github.com/AutomaticCoinTrader/ACT/notifier"
+)
+
+type TradeContext = exchange.TradeContext
+type Notifier = notifier.Notifier
+type TradeAlgorithm = algorithm.TradeAlgorithm
+type ArbitrageTradeAlgorithm = algorithm.ArbitrageTradeAlgorithm
+
+// NewAlgorithmSymbol is the exported symbol a compiled `.so` strategy plugin
+// must provide. Scripted (`.gop`) strategies define a top-level function of
+// the same name instead.
+const NewAlgorithmSymbol = "NewAlgorithm"
+
+// NewAlgorithmFunc is the signature plugin.Lookup and the Go+ interpreter
+// both resolve NewAlgorithmSymbol against for a single-exchange strategy.
+type NewAlgorithmFunc func() (TradeAlgorithm, error)
+
+// NewArbitrageAlgorithmFunc is the arbitrage equivalent of NewAlgorithmFunc.
+type NewArbitrageAlgorithmFunc func() (ArbitrageTradeAlgorithm, error)
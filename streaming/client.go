@@ -0,0 +1,341 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+const (
+	minBackoff = 1 * time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// ClientConfig holds the connection parameters for a single exchange
+// streaming endpoint.
+type ClientConfig struct {
+	URL       string `json:"url"       yaml:"url"       toml:"url"`
+	APIKey    string `json:"apiKey"    yaml:"apiKey"    toml:"apiKey"`
+	APISecret string `json:"apiSecret" yaml:"apiSecret" toml:"apiSecret"`
+}
+
+// Conn is the subset of *websocket.Conn the Client needs. It exists so
+// tests can drive Client with an in-memory fake instead of a real socket.
+type Conn interface {
+	ReadMessage() (messageType int, data []byte, err error)
+	WriteMessage(messageType int, data []byte) (error)
+	Close() (error)
+}
+
+type subscription struct {
+	symbol   string
+	channels map[ChannelType]int
+}
+
+type subscriberEntry struct {
+	id uint64
+	fn Subscriber
+}
+
+// Client maintains a single persistent WebSocket connection to an exchange
+// and multiplexes subscriptions to multiple symbols/channels over it.
+type Client struct {
+	name          string
+	config        *ClientConfig
+	dial          func(url string) (Conn, error)
+	mu            sync.Mutex
+	conn          Conn
+	subscriptions map[string]*subscription
+	subscribers   map[string][]subscriberEntry
+	nextID        uint64
+	finishChan    chan bool
+	recorder      *Recorder
+}
+
+// NewClient creates a Client for the named exchange. dial is injectable so
+// exchange packages can supply their own auth/handshake sequence, and so
+// tests can supply a fake Conn.
+func NewClient(name string, config *ClientConfig, dial func(url string) (Conn, error)) (*Client, error) {
+	if dial == nil {
+		dial = func(url string) (Conn, error) {
+			conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+			return conn, err
+		}
+	}
+	return &Client{
+		name:          name,
+		config:        config,
+		dial:          dial,
+		subscriptions: make(map[string]*subscription),
+		subscribers:   make(map[string][]subscriberEntry),
+		finishChan:    make(chan bool),
+	}, nil
+}
+
+// SetRecorder attaches a Recorder that persists every Message received so a
+// captured stream can later be fed through Replay.
+func (c *Client) SetRecorder(recorder *Recorder) {
+	c.recorder = recorder
+}
+
+// Connect dials the streaming endpoint, sends a subscribe frame for every
+// subscription registered before the connection existed, and starts the
+// read loop, which reconnects with exponential backoff on its own if the
+// connection drops.
+func (c *Client) Connect() (error) {
+	if err := c.reconnect(); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("can not connect to streaming endpoint (exchange = %v)", c.name))
+	}
+	go c.readLoop()
+	return nil
+}
+
+// Connected reports whether the underlying connection is currently up. It
+// backs the per-exchange status readyz exposes.
+func (c *Client) Connected() (bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn != nil
+}
+
+func (c *Client) readLoop() {
+	backoff := minBackoff
+	for {
+		select {
+		case <-c.finishChan:
+			return
+		default:
+		}
+		c.mu.Lock()
+		conn := c.conn
+		c.mu.Unlock()
+		if conn == nil {
+			time.Sleep(backoff)
+			if err := c.reconnect(); err != nil {
+				backoff = nextBackoff(backoff)
+				continue
+			}
+			backoff = minBackoff
+			continue
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			log.Printf("can not read from streaming endpoint (exchange = %v, reason = %v)", c.name, err)
+			c.mu.Lock()
+			c.conn = nil
+			c.mu.Unlock()
+			continue
+		}
+		backoff = minBackoff
+		message, err := decodeMessage(c.name, data)
+		if err != nil {
+			log.Printf("can not decode streaming message (exchange = %v, reason = %v)", c.name, err)
+			continue
+		}
+		if c.recorder != nil {
+			if err := c.recorder.Write(message); err != nil {
+				log.Printf("can not record streaming message (exchange = %v, reason = %v)", c.name, err)
+			}
+		}
+		c.dispatch(message)
+	}
+}
+
+// reconnect dials a fresh Conn and replays every live subscription onto it,
+// since the exchange's subscription state lives on the TCP connection and
+// does not survive the drop that triggered this reconnect.
+func (c *Client) reconnect() (error) {
+	conn, err := c.dial(c.config.URL)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.conn = conn
+	resubscribe := make(map[string][]ChannelType, len(c.subscriptions))
+	for symbol, sub := range c.subscriptions {
+		channels := make([]ChannelType, 0, len(sub.channels))
+		for channel := range sub.channels {
+			channels = append(channels, channel)
+		}
+		if len(channels) > 0 {
+			resubscribe[symbol] = channels
+		}
+	}
+	c.mu.Unlock()
+	for symbol, channels := range resubscribe {
+		if err := c.sendControl(conn, "subscribe", symbol, channels); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("can not resubscribe after reconnect (exchange = %v, symbol = %v)", c.name, symbol))
+		}
+	}
+	return nil
+}
+
+func nextBackoff(current time.Duration) (time.Duration) {
+	next := current * 2
+	if next > maxBackoff {
+		return maxBackoff
+	}
+	return next
+}
+
+func decodeMessage(exchangeName string, data []byte) (Message, error) {
+	var message Message
+	if err := json.Unmarshal(data, &message); err != nil {
+		return Message{}, err
+	}
+	message.Exchange = exchangeName
+	return message, nil
+}
+
+func (c *Client) dispatch(message Message) {
+	c.mu.Lock()
+	entries := append([]subscriberEntry{}, c.subscribers[message.Channel.subscriptionKey(message)]...)
+	c.mu.Unlock()
+	for _, entry := range entries {
+		entry.fn(message)
+	}
+}
+
+// subscriptionKey is unexported and only exists so dispatch can share the
+// same key format as Subscribe/unsubscribe below.
+func (channel ChannelType) subscriptionKey(message Message) (string) {
+	symbol := ""
+	switch channel {
+	case ChannelTrade:
+		if message.Trade != nil {
+			symbol = message.Trade.Symbol
+		}
+	case ChannelQuote:
+		if message.Quote != nil {
+			symbol = message.Quote.Symbol
+		}
+	case ChannelBar:
+		if message.Bar != nil {
+			symbol = message.Bar.Symbol
+		}
+	case ChannelDepth:
+		if message.Depth != nil {
+			symbol = message.Depth.Symbol
+		}
+	}
+	return subscriptionKeyOf(symbol, channel)
+}
+
+func subscriptionKeyOf(symbol string, channel ChannelType) (string) {
+	return fmt.Sprintf("%s:%s", symbol, channel)
+}
+
+// Subscription is a cancelable handle returned by Client.Subscribe. Each
+// channel keeps the subscriber id it was registered under so Cancel only
+// removes this caller's interest, not every subscriber on the same
+// symbol/channel.
+type Subscription struct {
+	client     *Client
+	symbol     string
+	channelIDs map[ChannelType]uint64
+}
+
+// Cancel unsubscribes from every channel this Subscription registered. Only
+// when the last subscriber for a given symbol/channel cancels does the
+// Client send an "unsubscribe" frame upstream.
+func (s *Subscription) Cancel() (error) {
+	return s.client.unsubscribe(s.symbol, s.channelIDs)
+}
+
+// Subscribe registers subscriber for symbol on the given channels, sending a
+// subscribe frame to the exchange the first time a symbol/channel pair is
+// requested. The returned Subscription can be canceled independently of
+// other subscribers on the same symbol/channel.
+func (c *Client) Subscribe(symbol string, subscriber Subscriber, channels ...ChannelType) (*Subscription, error) {
+	c.mu.Lock()
+	sub, ok := c.subscriptions[symbol]
+	if !ok {
+		sub = &subscription{symbol: symbol, channels: make(map[ChannelType]int)}
+		c.subscriptions[symbol] = sub
+	}
+	newChannels := make([]ChannelType, 0, len(channels))
+	channelIDs := make(map[ChannelType]uint64, len(channels))
+	for _, channel := range channels {
+		key := subscriptionKeyOf(symbol, channel)
+		c.nextID++
+		id := c.nextID
+		c.subscribers[key] = append(c.subscribers[key], subscriberEntry{id: id, fn: subscriber})
+		channelIDs[channel] = id
+		if sub.channels[channel] == 0 {
+			newChannels = append(newChannels, channel)
+		}
+		sub.channels[channel]++
+	}
+	conn := c.conn
+	c.mu.Unlock()
+	if len(newChannels) > 0 && conn != nil {
+		if err := c.sendControl(conn, "subscribe", symbol, newChannels); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("can not subscribe (exchange = %v, symbol = %v)", c.name, symbol))
+		}
+	}
+	return &Subscription{client: c, symbol: symbol, channelIDs: channelIDs}, nil
+}
+
+func (c *Client) unsubscribe(symbol string, channelIDs map[ChannelType]uint64) (error) {
+	c.mu.Lock()
+	sub := c.subscriptions[symbol]
+	closeChannels := make([]ChannelType, 0, len(channelIDs))
+	for channel, id := range channelIDs {
+		key := subscriptionKeyOf(symbol, channel)
+		entries := c.subscribers[key]
+		filtered := make([]subscriberEntry, 0, len(entries))
+		for _, entry := range entries {
+			if entry.id != id {
+				filtered = append(filtered, entry)
+			}
+		}
+		if len(filtered) == 0 {
+			delete(c.subscribers, key)
+		} else {
+			c.subscribers[key] = filtered
+		}
+		if sub == nil || sub.channels[channel] == 0 {
+			continue
+		}
+		sub.channels[channel]--
+		if sub.channels[channel] == 0 {
+			delete(sub.channels, channel)
+			closeChannels = append(closeChannels, channel)
+		}
+	}
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil || len(closeChannels) == 0 {
+		return nil
+	}
+	return c.sendControl(conn, "unsubscribe", symbol, closeChannels)
+}
+
+func (c *Client) sendControl(conn Conn, action string, symbol string, channels []ChannelType) (error) {
+	frame := struct {
+		Action   string        `json:"action"`
+		Symbol   string        `json:"symbol"`
+		Channels []ChannelType `json:"channels"`
+	}{Action: action, Symbol: symbol, Channels: channels}
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Close stops the read loop and closes the underlying connection.
+func (c *Client) Close() (error) {
+	close(c.finishChan)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
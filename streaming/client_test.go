@@ -0,0 +1,123 @@
+package streaming
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func newTestClient() (*Client) {
+	client, _ := NewClient("test", &ClientConfig{URL: "wss://example.test"}, func(url string) (Conn, error) {
+		return nil, nil
+	})
+	return client
+}
+
+// recordingConn is a fake Conn that keeps every frame written to it.
+type recordingConn struct {
+	writes []string
+}
+
+func (c *recordingConn) ReadMessage() (int, []byte, error) {
+	return 0, nil, io.EOF
+}
+
+func (c *recordingConn) WriteMessage(messageType int, data []byte) (error) {
+	c.writes = append(c.writes, string(data))
+	return nil
+}
+
+func (c *recordingConn) Close() (error) {
+	return nil
+}
+
+func TestSubscribeCancelOnlyRemovesOwnSubscriber(t *testing.T) {
+	client := newTestClient()
+
+	var aCalls, bCalls int
+	subA, err := client.Subscribe("BTCUSD", func(Message) { aCalls++ }, ChannelTrade)
+	if err != nil {
+		t.Fatalf("subscribe a: %v", err)
+	}
+	_, err = client.Subscribe("BTCUSD", func(Message) { bCalls++ }, ChannelTrade)
+	if err != nil {
+		t.Fatalf("subscribe b: %v", err)
+	}
+
+	if err := subA.Cancel(); err != nil {
+		t.Fatalf("cancel a: %v", err)
+	}
+
+	client.dispatch(Message{Channel: ChannelTrade, Trade: &Trade{Symbol: "BTCUSD"}})
+
+	if aCalls != 0 {
+		t.Errorf("canceled subscriber A was still called: %d", aCalls)
+	}
+	if bCalls != 1 {
+		t.Errorf("subscriber B should still receive messages, got %d calls", bCalls)
+	}
+}
+
+func TestUnsubscribeClearsChannelOnLastSubscriber(t *testing.T) {
+	client := newTestClient()
+
+	subA, err := client.Subscribe("BTCUSD", func(Message) {}, ChannelTrade)
+	if err != nil {
+		t.Fatalf("subscribe a: %v", err)
+	}
+	subB, err := client.Subscribe("BTCUSD", func(Message) {}, ChannelTrade)
+	if err != nil {
+		t.Fatalf("subscribe b: %v", err)
+	}
+
+	if err := subA.Cancel(); err != nil {
+		t.Fatalf("cancel a: %v", err)
+	}
+	sub := client.subscriptions["BTCUSD"]
+	if sub.channels[ChannelTrade] != 1 {
+		t.Fatalf("expected refcount 1 after first cancel, got %d", sub.channels[ChannelTrade])
+	}
+
+	if err := subB.Cancel(); err != nil {
+		t.Fatalf("cancel b: %v", err)
+	}
+	if _, stillSubscribed := sub.channels[ChannelTrade]; stillSubscribed {
+		t.Errorf("channel should be cleared once every subscriber cancels")
+	}
+	if len(client.subscribers[subscriptionKeyOf("BTCUSD", ChannelTrade)]) != 0 {
+		t.Errorf("subscribers map should have no entries left for the channel")
+	}
+}
+
+func TestSubscribeResendsAfterFullUnsubscribe(t *testing.T) {
+	client := newTestClient()
+
+	sub, err := client.Subscribe("BTCUSD", func(Message) {}, ChannelTrade)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := sub.Cancel(); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	// A second Subscribe after every prior subscriber canceled must be
+	// treated as new and resend a "subscribe" frame, not silently skipped
+	// because the channel refcount still counts the canceled subscriber.
+	client.mu.Lock()
+	existing := client.subscriptions["BTCUSD"].channels[ChannelTrade]
+	client.mu.Unlock()
+	if existing != 0 {
+		t.Fatalf("expected channel refcount to be 0 after full unsubscribe, got %d", existing)
+	}
+
+	conn := &recordingConn{}
+	client.mu.Lock()
+	client.conn = conn
+	client.mu.Unlock()
+	if _, err := client.Subscribe("BTCUSD", func(Message) {}, ChannelTrade); err != nil {
+		t.Fatalf("resubscribe: %v", err)
+	}
+	if len(conn.writes) != 1 || !strings.Contains(conn.writes[0], `"action":"subscribe"`) {
+		t.Errorf("expected one subscribe frame after full unsubscribe, got %q", conn.writes)
+	}
+}
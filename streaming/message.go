@@ -0,0 +1,77 @@
+package streaming
+
+import (
+	"time"
+)
+
+// ChannelType identifies the kind of market data carried by a Message.
+type ChannelType string
+
+const (
+	ChannelTrade ChannelType = "trade"
+	ChannelQuote ChannelType = "quote"
+	ChannelBar   ChannelType = "bar"
+	ChannelDepth ChannelType = "depth"
+)
+
+type Trade struct {
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	Size      float64   `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type Quote struct {
+	Symbol    string    `json:"symbol"`
+	BidPrice  float64   `json:"bidPrice"`
+	BidSize   float64   `json:"bidSize"`
+	AskPrice  float64   `json:"askPrice"`
+	AskSize   float64   `json:"askSize"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type Bar struct {
+	Symbol    string    `json:"symbol"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type DepthLevel struct {
+	Price float64 `json:"price"`
+	Size  float64 `json:"size"`
+}
+
+type Depth struct {
+	Symbol    string       `json:"symbol"`
+	Bids      []DepthLevel `json:"bids"`
+	Asks      []DepthLevel `json:"asks"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// MessageApplier is implemented by a TradeContext that can absorb a
+// streaming Message into its own state. A Subscribe callback type-asserts
+// the TradeContext it already has against this interface and applies the
+// message before re-running the algorithm, so a tick received over the
+// multiplexed socket actually changes what the next Update sees instead of
+// just re-running Update against an untouched snapshot.
+type MessageApplier interface {
+	ApplyMessage(message Message) (error)
+}
+
+// Message is the envelope fanned out to Subscribers. Exactly one of the
+// typed fields is set, matching Channel.
+type Message struct {
+	Exchange string      `json:"exchange"`
+	Channel  ChannelType `json:"channel"`
+	Trade    *Trade      `json:"trade,omitempty"`
+	Quote    *Quote      `json:"quote,omitempty"`
+	Bar      *Bar        `json:"bar,omitempty"`
+	Depth    *Depth      `json:"depth,omitempty"`
+}
+
+// Subscriber receives every Message delivered for the channels it subscribed to.
+type Subscriber func(message Message)
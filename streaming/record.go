@@ -0,0 +1,61 @@
+package streaming
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Recorder appends every Message it is given to an underlying writer as a
+// single JSON object per line, so a captured live stream can later drive a
+// backtest through Replay without algorithm code knowing the difference.
+type Recorder struct {
+	mu     sync.Mutex
+	writer *bufio.Writer
+}
+
+// NewRecorder wraps w in a Recorder. The caller owns w and is responsible
+// for closing it once recording stops.
+func NewRecorder(w io.Writer) (*Recorder) {
+	return &Recorder{
+		writer: bufio.NewWriter(w),
+	}
+}
+
+// Write appends message as a single JSON line and flushes it.
+func (r *Recorder) Write(message Message) (error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err := r.writer.Write(data); err != nil {
+		return err
+	}
+	if err := r.writer.WriteByte('\n'); err != nil {
+		return err
+	}
+	return r.writer.Flush()
+}
+
+// Replay reads JSON-lines previously produced by a Recorder from r and
+// invokes subscriber for each Message in file order, which is assumed to be
+// monotonic in time.
+func Replay(r io.Reader, subscriber Subscriber) (error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var message Message
+		if err := json.Unmarshal(line, &message); err != nil {
+			return err
+		}
+		subscriber(message)
+	}
+	return scanner.Err()
+}